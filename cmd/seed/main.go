@@ -0,0 +1,72 @@
+// Command seed populates reference and sample data (categories, sellers,
+// products) against a database that has already had migrations applied.
+//
+// Usage:
+//
+//	go run ./cmd/seed [--only categories,products] [--reset]
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+
+	"yadwy-backend/database/seeds"
+	"yadwy-backend/internal/database"
+)
+
+func main() {
+	only := flag.String("only", "", "comma-separated list of seed sets to run (default: all)")
+	reset := flag.Bool("reset", false, "truncate the relevant tables before seeding")
+	flag.Parse()
+
+	config := configFromEnv()
+
+	db, err := database.NewPostgresDB(config)
+	if err != nil {
+		slog.Error("failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	var names []string
+	if *only != "" {
+		names = strings.Split(*only, ",")
+	}
+
+	if err := seeds.Run(context.Background(), db, names, *reset); err != nil {
+		slog.Error("failed to seed database", "error", err)
+		os.Exit(1)
+	}
+
+	slog.Info("seeding completed successfully", "sets", orAll(names))
+}
+
+func orAll(names []string) []string {
+	if len(names) == 0 {
+		return seeds.Names()
+	}
+	return names
+}
+
+func configFromEnv() database.Config {
+	port, _ := strconv.Atoi(getEnv("DB_PORT", "5432"))
+	return database.Config{
+		Host:     getEnv("DB_HOST", "localhost"),
+		Port:     port,
+		User:     getEnv("DB_USER", "postgres"),
+		Password: getEnv("DB_PASSWORD", ""),
+		DBName:   getEnv("DB_NAME", "yadwy"),
+		SSLMode:  getEnv("DB_SSLMODE", "disable"),
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
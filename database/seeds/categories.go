@@ -0,0 +1,38 @@
+package seeds
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func init() {
+	Register("categories", seedCategories, "categories")
+}
+
+type category struct {
+	ID   string `db:"id"`
+	Name string `db:"name"`
+}
+
+var sampleCategories = []category{
+	{ID: "electronics", Name: "Electronics"},
+	{ID: "home-and-kitchen", Name: "Home & Kitchen"},
+	{ID: "fashion", Name: "Fashion"},
+	{ID: "beauty", Name: "Beauty"},
+	{ID: "toys-and-games", Name: "Toys & Games"},
+}
+
+func seedCategories(ctx context.Context, db *sqlx.DB) error {
+	const query = `
+		INSERT INTO categories (id, name)
+		VALUES (:id, :name)
+		ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name`
+
+	for _, c := range sampleCategories {
+		if _, err := db.NamedExecContext(ctx, query, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package seeds
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func init() {
+	Register("products", seedProducts, "products")
+}
+
+type product struct {
+	Name        string   `db:"name"`
+	Description string   `db:"description"`
+	Price       float64  `db:"price"`
+	CategoryID  string   `db:"category_id"`
+	SellerID    int64    `db:"seller_id"`
+	Stock       int      `db:"stock"`
+	IsAvailable bool     `db:"is_available"`
+	Labels      []string `db:"labels"`
+}
+
+var sampleProducts = []product{
+	{
+		Name:        "Wireless Mouse",
+		Description: "Ergonomic wireless mouse with USB-C charging.",
+		Price:       19.99,
+		CategoryID:  "electronics",
+		SellerID:    2,
+		Stock:       50,
+		IsAvailable: true,
+		Labels:      []string{"new", "best-seller"},
+	},
+	{
+		Name:        "Ceramic Mug Set",
+		Description: "Set of four 350ml ceramic mugs.",
+		Price:       24.50,
+		CategoryID:  "home-and-kitchen",
+		SellerID:    1,
+		Stock:       120,
+		IsAvailable: true,
+		Labels:      []string{"kitchen"},
+	},
+	{
+		Name:        "Canvas Tote Bag",
+		Description: "Durable cotton canvas tote bag.",
+		Price:       12.00,
+		CategoryID:  "fashion",
+		SellerID:    1,
+		Stock:       80,
+		IsAvailable: true,
+		Labels:      []string{"eco-friendly"},
+	},
+}
+
+// seedProducts is idempotent on (seller_id, name) since the products table
+// has no natural-key unique constraint to ON CONFLICT against.
+func seedProducts(ctx context.Context, db *sqlx.DB) error {
+	const query = `
+		INSERT INTO products (name, description, price, category_id, seller_id, stock, is_available, labels)
+		SELECT :name, :description, :price, :category_id, :seller_id, :stock, :is_available, :labels
+		WHERE NOT EXISTS (
+			SELECT 1 FROM products WHERE seller_id = :seller_id AND name = :name
+		)`
+
+	for _, p := range sampleProducts {
+		if _, err := db.NamedExecContext(ctx, query, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Package seeds populates reference and sample data for local development
+// and tests. Individual seed sets register themselves via Register so new
+// ones can be added without touching the runner in this file.
+package seeds
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// SeedFunc populates one set of reference data. Implementations must be
+// idempotent (upsert on a natural key) so Run can be called repeatedly,
+// e.g. on every app start in dev.
+type SeedFunc func(ctx context.Context, db *sqlx.DB) error
+
+type registration struct {
+	name   string
+	fn     SeedFunc
+	tables []string // truncated when --reset is passed
+}
+
+var registry []registration
+
+// Register adds a named seed set. tables lists the tables Run truncates for
+// this seed set when reset is true; it may be empty for seeds with nothing
+// to truncate.
+func Register(name string, fn SeedFunc, tables ...string) {
+	registry = append(registry, registration{name: name, fn: fn, tables: tables})
+}
+
+// Names returns every registered seed name, in registration order.
+func Names() []string {
+	names := make([]string, len(registry))
+	for i, r := range registry {
+		names[i] = r.name
+	}
+	return names
+}
+
+// Run executes the named seed sets in registration order (so "categories"
+// can run before "products" without callers needing to know the
+// dependency). An empty only runs every registered seed. If reset is true,
+// each selected seed's tables are truncated first.
+func Run(ctx context.Context, db *sqlx.DB, only []string, reset bool) error {
+	selected := selectRegistrations(only)
+
+	if reset {
+		if err := resetTables(ctx, db, selected); err != nil {
+			return err
+		}
+	}
+
+	for _, r := range selected {
+		if err := r.fn(ctx, db); err != nil {
+			return fmt.Errorf("seed %q: %w", r.name, err)
+		}
+	}
+	return nil
+}
+
+func selectRegistrations(only []string) []registration {
+	if len(only) == 0 {
+		return registry
+	}
+
+	wanted := make(map[string]bool, len(only))
+	for _, name := range only {
+		wanted[name] = true
+	}
+
+	var selected []registration
+	for _, r := range registry {
+		if wanted[r.name] {
+			selected = append(selected, r)
+		}
+	}
+	return selected
+}
+
+func resetTables(ctx context.Context, db *sqlx.DB, selected []registration) error {
+	seen := make(map[string]bool)
+	var tables []string
+	for _, r := range selected {
+		for _, t := range r.tables {
+			if !seen[t] {
+				seen[t] = true
+				tables = append(tables, t)
+			}
+		}
+	}
+	if len(tables) == 0 {
+		return nil
+	}
+
+	// CASCADE so truncating a referenced table (e.g. categories) also
+	// clears dependents (e.g. products) regardless of selection order.
+	for _, t := range tables {
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("TRUNCATE TABLE %s CASCADE", t)); err != nil {
+			return fmt.Errorf("truncate %q: %w", t, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package seeds
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func init() {
+	Register("sellers", seedSellers, "sellers")
+}
+
+type seller struct {
+	ID    int64  `db:"id"`
+	Name  string `db:"name"`
+	Email string `db:"email"`
+}
+
+var sampleSellers = []seller{
+	{ID: 1, Name: "Yadwy Home Store", Email: "home-store@yadwy.test"},
+	{ID: 2, Name: "Yadwy Gadgets", Email: "gadgets@yadwy.test"},
+}
+
+func seedSellers(ctx context.Context, db *sqlx.DB) error {
+	const query = `
+		INSERT INTO sellers (id, name, email)
+		VALUES (:id, :name, :email)
+		ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name, email = EXCLUDED.email`
+
+	for _, s := range sampleSellers {
+		if _, err := db.NamedExecContext(ctx, query, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
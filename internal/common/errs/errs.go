@@ -0,0 +1,105 @@
+// Package errs provides the structured error type used across the
+// application instead of ad-hoc string error codes passed straight to
+// common.SendError. Handlers return an *Error (or any error wrapping one)
+// and let Middleware render it as an RFC 7807 problem+json response.
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Kind classifies an Error for the purpose of choosing an HTTP status and a
+// response Title. It intentionally stays small and generic rather than
+// growing one value per failure mode.
+type Kind string
+
+const (
+	KindValidation Kind = "validation"
+	KindNotFound   Kind = "not_found"
+	KindConflict   Kind = "conflict"
+	KindAuth       Kind = "auth"
+	KindInternal   Kind = "internal"
+)
+
+// Field is one field-level validation failure, rendered as a problem+json
+// extension member so clients can show errors next to the offending input.
+type Field struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// Error is the structured error type services and handlers return in place
+// of bare string codes. Code identifies the failure for API clients and
+// logs; Kind decides the HTTP status; Message is the text rendered to
+// clients (except for KindInternal); Cause, when set, is the underlying
+// error (e.g. a database error). Wrap copies Cause's text into Message.
+type Error struct {
+	Code    string
+	Kind    Kind
+	Message string
+	Cause   error
+	Fields  []Field
+}
+
+func (e *Error) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Cause)
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error { return e.Cause }
+
+// New builds an Error of kind with no wrapped cause.
+func New(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// Wrap builds an Error of kind around cause, keeping cause reachable via
+// errors.Unwrap/errors.Is so callers further up the stack can still inspect
+// it (e.g. to detect sql.ErrNoRows) without parsing Message.
+func Wrap(kind Kind, code string, cause error) *Error {
+	return &Error{Kind: kind, Code: code, Message: cause.Error(), Cause: cause}
+}
+
+// WithFields attaches per-field validation details and returns e, so it can
+// be chained at the construction site.
+func (e *Error) WithFields(fields ...Field) *Error {
+	e.Fields = fields
+	return e
+}
+
+// As reports whether err (or an error in its chain) is an *Error, returning
+// it if so.
+func As(err error) (*Error, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
+// Is reports whether err (or an error in its chain) is an *Error of kind.
+func Is(err error, kind Kind) bool {
+	e, ok := As(err)
+	return ok && e.Kind == kind
+}
+
+// StatusFor maps a Kind to the HTTP status it renders as. Unrecognized
+// kinds (including the zero value) map to 500, matching KindInternal.
+func StatusFor(kind Kind) int {
+	switch kind {
+	case KindValidation:
+		return http.StatusBadRequest
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindConflict:
+		return http.StatusConflict
+	case KindAuth:
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,18 @@
+package errs
+
+import "net/http"
+
+// HandlerFunc is like http.HandlerFunc but can fail with a structured
+// error instead of writing a response directly.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// Middleware adapts a HandlerFunc into an http.HandlerFunc, rendering any
+// returned error via WriteJSON so individual handlers don't each need to
+// map Kind to an HTTP status themselves.
+func Middleware(next HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := next(w, r); err != nil {
+			WriteJSON(w, err)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package errs
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Problem is an RFC 7807 (application/problem+json) error body.
+type Problem struct {
+	Type   string  `json:"type"`
+	Title  string  `json:"title"`
+	Status int     `json:"status"`
+	Detail string  `json:"detail,omitempty"`
+	Code   string  `json:"code,omitempty"`
+	Fields []Field `json:"fields,omitempty"`
+}
+
+// WriteJSON renders err as an RFC 7807 problem+json response. If err isn't
+// an *Error (or doesn't wrap one), it's treated as an unclassified internal
+// error rather than rejected, so callers never need a type-assertion
+// fallback of their own.
+func WriteJSON(w http.ResponseWriter, err error) {
+	e, ok := As(err)
+	if !ok {
+		e = &Error{Kind: KindInternal, Code: "internal-error", Message: err.Error()}
+	}
+
+	status := StatusFor(e.Kind)
+
+	// Internal failures (DB errors, I/O errors, ...) may carry detail
+	// clients shouldn't see; every other kind's Message is meant for them.
+	detail := e.Message
+	if e.Kind == KindInternal {
+		detail = "an unexpected error occurred"
+	}
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(Problem{
+		Type:   "about:blank",
+		Title:  string(e.Kind),
+		Status: status,
+		Detail: detail,
+		Code:   e.Code,
+		Fields: e.Fields,
+	})
+}
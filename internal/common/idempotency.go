@@ -0,0 +1,94 @@
+package common
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// IdempotencyRecord is a previously persisted response for an
+// Idempotency-Key, used to detect retries and replay the original response
+// instead of repeating the request's side effects.
+type IdempotencyRecord struct {
+	Key            string    `db:"key"`
+	RequestHash    string    `db:"request_hash"`
+	ResponseStatus int       `db:"response_status"`
+	ResponseBody   []byte    `db:"response_body"`
+	CreatedAt      time.Time `db:"created_at"`
+}
+
+// IdempotencyStore persists idempotency keys so a handler can tell a retried
+// request (same key, same body hash -> replay the cached response) apart
+// from a key reused for a different request (-> caller should get a
+// conflict) instead of repeating the work the key guards.
+type IdempotencyStore struct {
+	db  *sqlx.DB
+	ttl time.Duration
+}
+
+func NewIdempotencyStore(db *sqlx.DB, ttl time.Duration) *IdempotencyStore {
+	return &IdempotencyStore{db: db, ttl: ttl}
+}
+
+// Get returns the record stored for key, or nil if key hasn't been seen yet.
+func (s *IdempotencyStore) Get(ctx context.Context, key string) (*IdempotencyRecord, error) {
+	var rec IdempotencyRecord
+	const query = `SELECT * FROM idempotency_keys WHERE key = $1`
+	if err := s.db.GetContext(ctx, &rec, query, key); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get idempotency key %q: %w", key, err)
+	}
+	return &rec, nil
+}
+
+// Save records the response produced for key so a retry can replay it. The
+// first save for a key wins; concurrent duplicate saves are a no-op rather
+// than an error.
+func (s *IdempotencyStore) Save(ctx context.Context, key, requestHash string, status int, body []byte) error {
+	const query = `
+		INSERT INTO idempotency_keys (key, request_hash, response_status, response_body)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (key) DO NOTHING`
+	if _, err := s.db.ExecContext(ctx, query, key, requestHash, status, body); err != nil {
+		return fmt.Errorf("save idempotency key %q: %w", key, err)
+	}
+	return nil
+}
+
+// Sweep deletes records older than the store's TTL and returns how many
+// were removed.
+func (s *IdempotencyStore) Sweep(ctx context.Context) (int64, error) {
+	const query = `DELETE FROM idempotency_keys WHERE created_at < now() - make_interval(secs => $1)`
+	res, err := s.db.ExecContext(ctx, query, s.ttl.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf("sweep idempotency keys: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// StartSweeper runs Sweep on interval until ctx is canceled, logging (rather
+// than aborting on) individual sweep failures.
+func (s *IdempotencyStore) StartSweeper(ctx context.Context, interval time.Duration, logger *zap.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if removed, err := s.Sweep(ctx); err != nil {
+				logger.Error("failed to sweep expired idempotency keys", zap.Error(err))
+			} else if removed > 0 {
+				logger.Info("swept expired idempotency keys", zap.Int64("removed", removed))
+			}
+		}
+	}
+}
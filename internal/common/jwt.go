@@ -0,0 +1,47 @@
+package common
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWTGenerator issues and verifies the HS256 bearer tokens used to
+// authenticate API requests.
+type JWTGenerator struct {
+	secret []byte
+}
+
+func NewJWTGenerator(secret string) *JWTGenerator {
+	return &JWTGenerator{secret: []byte(secret)}
+}
+
+func (g *JWTGenerator) Generate(claims jwt.Claims) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(g.secret)
+}
+
+func (g *JWTGenerator) Parse(tokenString string, claims jwt.Claims) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(tokenString, claims, func(*jwt.Token) (any, error) {
+		return g.secret, nil
+	})
+}
+
+// GetAuthMiddlewareFunc returns middleware that rejects requests without a
+// valid bearer token signed by g.
+func GetAuthMiddlewareFunc(g *JWTGenerator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := r.Header.Get("Authorization")
+			if token == "" {
+				SendError(w, http.StatusUnauthorized, "missing-authorization", "Authorization header is required")
+				return
+			}
+			if _, err := g.Parse(strings.TrimPrefix(token, "Bearer "), jwt.MapClaims{}); err != nil {
+				SendError(w, http.StatusUnauthorized, "invalid-token", "Bearer token is invalid")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
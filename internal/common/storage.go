@@ -0,0 +1,75 @@
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Storage abstracts where uploaded files and derived assets live, so the
+// same application code can target local disk in dev, S3 in production, or
+// a CDN origin that only speaks HTTP PUT.
+type Storage interface {
+	// Put uploads data under key and returns its public (or origin) URL.
+	Put(ctx context.Context, key string, r io.Reader, contentType string) (url string, err error)
+	// Delete removes the object stored under key.
+	Delete(ctx context.Context, key string) error
+	// SignedURL returns a time-limited URL for private buckets; backends
+	// that only serve public objects may just return the plain URL.
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (url string, err error)
+}
+
+// StorageConfig selects and configures a Storage backend. Only the fields
+// relevant to Driver need to be set.
+type StorageConfig struct {
+	Driver string // "local" | "s3" | "http"
+
+	LocalDir     string
+	LocalBaseURL string
+
+	S3Bucket  string
+	S3Region  string
+	S3Prefix  string
+	S3Private bool
+
+	HTTPEndpoint   string
+	HTTPAuthHeader string
+}
+
+// NewStorage builds the Storage backend selected by cfg.Driver.
+func NewStorage(cfg StorageConfig) (Storage, error) {
+	switch cfg.Driver {
+	case "", "local":
+		return NewLocalStorage(cfg.LocalDir, cfg.LocalBaseURL)
+	case "s3":
+		return NewS3Storage(cfg.S3Bucket, cfg.S3Region, cfg.S3Prefix, cfg.S3Private)
+	case "http":
+		return NewHTTPStorage(cfg.HTTPEndpoint, cfg.HTTPAuthHeader), nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+	}
+}
+
+// ContentHashKey reads r fully and returns a content-addressable key
+// (prefix/<sha256>.ext) along with the bytes read, so identical uploads
+// always resolve to the same key and naturally dedupe in the backing
+// store.
+func ContentHashKey(r io.Reader, prefix, ext string) (key string, data []byte, err error) {
+	data, err = io.ReadAll(r)
+	if err != nil {
+		return "", nil, fmt.Errorf("read upload: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	if prefix != "" {
+		key = prefix + "/" + hash + ext
+	} else {
+		key = hash + ext
+	}
+	return key, data, nil
+}
@@ -0,0 +1,80 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HTTPStorage uploads objects to an HTTP origin via PUT, suitable for
+// CDN origins (e.g. BunnyCDN storage zones) that expose a simple
+// PUT-to-write / GET-to-read API over HTTP.
+type HTTPStorage struct {
+	endpoint   string
+	authHeader string
+	client     *http.Client
+}
+
+func NewHTTPStorage(endpoint, authHeader string) *HTTPStorage {
+	return &HTTPStorage{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		authHeader: authHeader,
+		client:     &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (s *HTTPStorage) url(key string) string {
+	return s.endpoint + "/" + key
+}
+
+func (s *HTTPStorage) Put(ctx context.Context, key string, r io.Reader, contentType string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.url(key), r)
+	if err != nil {
+		return "", fmt.Errorf("build put request for %q: %w", key, err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if s.authHeader != "" {
+		req.Header.Set("AccessKey", s.authHeader)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("put %q: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return "", fmt.Errorf("put %q: unexpected status %s", key, resp.Status)
+	}
+	return s.url(key), nil
+}
+
+func (s *HTTPStorage) Delete(ctx context.Context, key string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.url(key), nil)
+	if err != nil {
+		return fmt.Errorf("build delete request for %q: %w", key, err)
+	}
+	if s.authHeader != "" {
+		req.Header.Set("AccessKey", s.authHeader)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("delete %q: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("delete %q: unexpected status %s", key, resp.Status)
+	}
+	return nil
+}
+
+// SignedURL has nothing to sign for a public CDN origin, so it returns the
+// plain URL; ttl is ignored.
+func (s *HTTPStorage) SignedURL(_ context.Context, key string, _ time.Duration) (string, error) {
+	return s.url(key), nil
+}
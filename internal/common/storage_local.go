@@ -0,0 +1,56 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LocalStorage stores objects on the local filesystem under dir and serves
+// them back from baseURL. It is meant for local development.
+type LocalStorage struct {
+	dir     string
+	baseURL string
+}
+
+func NewLocalStorage(dir, baseURL string) (*LocalStorage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create storage dir %q: %w", dir, err)
+	}
+	return &LocalStorage{dir: dir, baseURL: baseURL}, nil
+}
+
+func (s *LocalStorage) Put(_ context.Context, key string, r io.Reader, _ string) (string, error) {
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("create dir for %q: %w", key, err)
+	}
+
+	dst, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("create file %q: %w", key, err)
+	}
+	defer dst.Close()
+
+	if _, err := io.Copy(dst, r); err != nil {
+		return "", fmt.Errorf("write file %q: %w", key, err)
+	}
+
+	return s.baseURL + "/" + key, nil
+}
+
+func (s *LocalStorage) Delete(_ context.Context, key string) error {
+	if err := os.Remove(filepath.Join(s.dir, filepath.FromSlash(key))); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("delete file %q: %w", key, err)
+	}
+	return nil
+}
+
+// SignedURL has nothing to sign for local storage, so it returns the plain
+// public URL; ttl is ignored.
+func (s *LocalStorage) SignedURL(_ context.Context, key string, _ time.Duration) (string, error) {
+	return s.baseURL + "/" + key, nil
+}
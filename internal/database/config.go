@@ -0,0 +1,17 @@
+package database
+
+// Config holds the settings needed to connect to PostgreSQL and to control
+// optional behavior around migrations.
+type Config struct {
+	Host     string
+	Port     int
+	User     string
+	Password string
+	DBName   string
+	SSLMode  string
+
+	// SeedOnMigrate runs the database/seeds package against the database
+	// right after RunMigrations applies pending migrations. It should only
+	// be enabled in dev/test environments.
+	SeedOnMigrate bool
+}
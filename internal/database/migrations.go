@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"github.com/jmoiron/sqlx"
 	"log/slog"
@@ -8,6 +9,8 @@ import (
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+
+	"yadwy-backend/database/seeds"
 )
 
 func RunMigrations(config Config) error {
@@ -43,5 +46,13 @@ func RunMigrations(config Config) error {
 	}
 
 	slog.Info("Database migrations completed successfully")
+
+	if config.SeedOnMigrate {
+		if err := seeds.Run(context.Background(), db, nil, false); err != nil {
+			return fmt.Errorf("failed to seed database: %w", err)
+		}
+		slog.Info("Database seeding completed successfully")
+	}
+
 	return nil
 }
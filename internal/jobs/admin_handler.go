@@ -0,0 +1,91 @@
+package jobs
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"yadwy-backend/internal/common"
+)
+
+const (
+	InvalidJobID      = "invalid-job-id"
+	JobNotFound       = "job-not-found"
+	FailedToListJobs  = "failed-to-list-jobs"
+	FailedToRetryJob  = "failed-to-retry-job"
+	FailedToCancelJob = "failed-to-cancel-job"
+)
+
+// AdminHandler exposes operational endpoints for inspecting and managing
+// the job queue: listing recent jobs and retrying or canceling one by ID.
+type AdminHandler struct {
+	queue  *Queue
+	logger *zap.Logger
+}
+
+func NewAdminHandler(queue *Queue, logger *zap.Logger) *AdminHandler {
+	return &AdminHandler{queue: queue, logger: logger}
+}
+
+// LoadAdminRoutes returns a router with the job admin endpoints (list, retry,
+// cancel); the caller mounts it, e.g. under /admin/jobs.
+func LoadAdminRoutes(queue *Queue, logger *zap.Logger) http.Handler {
+	h := NewAdminHandler(queue, logger)
+	ar := chi.NewRouter()
+	ar.Get("/", h.ListJobs)
+	ar.Post("/{id}/retry", h.RetryJob)
+	ar.Post("/{id}/cancel", h.CancelJob)
+	return ar
+}
+
+func (h *AdminHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	const defaultLimit = 100
+	jobs, err := h.queue.List(r.Context(), defaultLimit)
+	if err != nil {
+		h.logger.Error("failed to list jobs", zap.Error(err))
+		common.SendError(w, http.StatusInternalServerError, FailedToListJobs, err.Error())
+		return
+	}
+
+	if err := common.Encode(w, http.StatusOK, jobs); err != nil {
+		h.logger.Error("failed to encode jobs", zap.Error(err))
+	}
+}
+
+func (h *AdminHandler) RetryJob(w http.ResponseWriter, r *http.Request) {
+	id, err := parseJobID(r)
+	if err != nil {
+		common.SendError(w, http.StatusBadRequest, InvalidJobID, err.Error())
+		return
+	}
+
+	if err := h.queue.RequeueForRetry(r.Context(), id); err != nil {
+		h.logger.Error("failed to retry job", zap.Int64("job_id", id), zap.Error(err))
+		common.SendError(w, http.StatusInternalServerError, FailedToRetryJob, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AdminHandler) CancelJob(w http.ResponseWriter, r *http.Request) {
+	id, err := parseJobID(r)
+	if err != nil {
+		common.SendError(w, http.StatusBadRequest, InvalidJobID, err.Error())
+		return
+	}
+
+	if err := h.queue.Cancel(r.Context(), id); err != nil {
+		h.logger.Error("failed to cancel job", zap.Int64("job_id", id), zap.Error(err))
+		common.SendError(w, http.StatusInternalServerError, FailedToCancelJob, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func parseJobID(r *http.Request) (int64, error) {
+	return strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+}
@@ -0,0 +1,38 @@
+package jobs
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Status is the lifecycle state of a Job.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusDead      Status = "dead"
+)
+
+// Kinds of work the product service defers to the job queue.
+const (
+	KindImageProcessing = "image_processing"
+	KindOutboundWebhook = "outbound_webhook"
+)
+
+// Job is a single unit of deferred work, durably stored in the jobs table
+// and claimed by a JobWorker via SELECT ... FOR UPDATE SKIP LOCKED.
+type Job struct {
+	ID          int64           `db:"id" json:"id"`
+	Kind        string          `db:"kind" json:"kind"`
+	Payload     json.RawMessage `db:"payload" json:"payload"`
+	Status      Status          `db:"status" json:"status"`
+	Attempts    int             `db:"attempts" json:"attempts"`
+	MaxAttempts int             `db:"max_attempts" json:"max_attempts"`
+	RunAfter    time.Time       `db:"run_after" json:"run_after"`
+	LastError   *string         `db:"last_error" json:"last_error,omitempty"`
+	CreatedAt   time.Time       `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time       `db:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,161 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const defaultMaxAttempts = 5
+
+// Queue is the PostgreSQL-backed durable job store. Enqueue is called by
+// application services that want work done asynchronously; Dequeue is used
+// by a JobWorker to claim the next runnable job.
+type Queue struct {
+	db *sqlx.DB
+}
+
+func NewQueue(db *sqlx.DB) *Queue {
+	return &Queue{db: db}
+}
+
+// Enqueue stores a new pending job of the given kind with payload marshaled
+// to JSON, and returns it with its assigned ID.
+func (q *Queue) Enqueue(ctx context.Context, kind string, payload any) (*Job, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshal payload for job %q: %w", kind, err)
+	}
+
+	var job Job
+	const query = `
+		INSERT INTO jobs (kind, payload, max_attempts)
+		VALUES ($1, $2, $3)
+		RETURNING *`
+	if err := q.db.GetContext(ctx, &job, query, kind, raw, defaultMaxAttempts); err != nil {
+		return nil, fmt.Errorf("enqueue job %q: %w", kind, err)
+	}
+	return &job, nil
+}
+
+// Dequeue claims up to one pending, due job for the calling worker using
+// SELECT ... FOR UPDATE SKIP LOCKED, so multiple workers can poll the same
+// table concurrently without claiming the same row twice. It returns
+// (nil, nil) when there is nothing to do.
+func (q *Queue) Dequeue(ctx context.Context) (*Job, error) {
+	tx, err := q.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin dequeue tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var job Job
+	const selectQuery = `
+		SELECT * FROM jobs
+		WHERE status = 'pending' AND run_after <= now()
+		ORDER BY run_after
+		FOR UPDATE SKIP LOCKED
+		LIMIT 1`
+	if err := tx.GetContext(ctx, &job, selectQuery); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("select next job: %w", err)
+	}
+
+	const updateQuery = `UPDATE jobs SET status = 'running', attempts = attempts + 1, updated_at = now() WHERE id = $1`
+	if _, err := tx.ExecContext(ctx, updateQuery, job.ID); err != nil {
+		return nil, fmt.Errorf("claim job %d: %w", job.ID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit dequeue tx: %w", err)
+	}
+
+	job.Status = StatusRunning
+	job.Attempts++
+	return &job, nil
+}
+
+// Succeed marks job as succeeded.
+func (q *Queue) Succeed(ctx context.Context, jobID int64) error {
+	const query = `UPDATE jobs SET status = 'succeeded', updated_at = now() WHERE id = $1`
+	_, err := q.db.ExecContext(ctx, query, jobID)
+	return err
+}
+
+// Retry reschedules job to run again after delaySeconds, recording cause as
+// the last failure reason.
+func (q *Queue) Retry(ctx context.Context, job *Job, cause error, delaySeconds int) error {
+	const query = `
+		UPDATE jobs
+		SET status = 'pending', run_after = now() + make_interval(secs => $2), last_error = $3, updated_at = now()
+		WHERE id = $1`
+	_, err := q.db.ExecContext(ctx, query, job.ID, delaySeconds, cause.Error())
+	return err
+}
+
+// MoveToDeadLetter marks job as dead and copies it into dead_letter_jobs
+// after it has exhausted its retry budget.
+func (q *Queue) MoveToDeadLetter(ctx context.Context, job *Job, cause error) error {
+	tx, err := q.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin dead-letter tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	const updateQuery = `UPDATE jobs SET status = 'dead', last_error = $2, updated_at = now() WHERE id = $1`
+	if _, err := tx.ExecContext(ctx, updateQuery, job.ID, cause.Error()); err != nil {
+		return fmt.Errorf("mark job %d dead: %w", job.ID, err)
+	}
+
+	const insertQuery = `
+		INSERT INTO dead_letter_jobs (job_id, kind, payload, last_error)
+		VALUES ($1, $2, $3, $4)`
+	if _, err := tx.ExecContext(ctx, insertQuery, job.ID, job.Kind, job.Payload, cause.Error()); err != nil {
+		return fmt.Errorf("insert dead letter for job %d: %w", job.ID, err)
+	}
+
+	return tx.Commit()
+}
+
+// List returns jobs ordered by most recently created, for the admin endpoint.
+func (q *Queue) List(ctx context.Context, limit int) ([]Job, error) {
+	var list []Job
+	const query = `SELECT * FROM jobs ORDER BY created_at DESC LIMIT $1`
+	if err := q.db.SelectContext(ctx, &list, query, limit); err != nil {
+		return nil, fmt.Errorf("list jobs: %w", err)
+	}
+	return list, nil
+}
+
+// ListByProduct returns every job whose payload references productID,
+// newest first, so callers can derive an aggregate completion status.
+func (q *Queue) ListByProduct(ctx context.Context, productID int64) ([]Job, error) {
+	var list []Job
+	const query = `SELECT * FROM jobs WHERE (payload->>'product_id')::bigint = $1 ORDER BY created_at DESC`
+	if err := q.db.SelectContext(ctx, &list, query, productID); err != nil {
+		return nil, fmt.Errorf("list jobs for product %d: %w", productID, err)
+	}
+	return list, nil
+}
+
+// Cancel deletes a pending job so it never runs. It is a no-op once a job
+// has started running.
+func (q *Queue) Cancel(ctx context.Context, jobID int64) error {
+	const query = `DELETE FROM jobs WHERE id = $1 AND status = 'pending'`
+	_, err := q.db.ExecContext(ctx, query, jobID)
+	return err
+}
+
+// RequeueForRetry resets a dead or failed job back to pending, for manual
+// retries triggered from the admin endpoint.
+func (q *Queue) RequeueForRetry(ctx context.Context, jobID int64) error {
+	const query = `UPDATE jobs SET status = 'pending', attempts = 0, run_after = now(), updated_at = now() WHERE id = $1`
+	_, err := q.db.ExecContext(ctx, query, jobID)
+	return err
+}
@@ -0,0 +1,131 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// HandlerFunc executes one job's payload. Returning an error triggers a
+// retry (with exponential backoff) until the job's MaxAttempts is reached,
+// at which point it is moved to the dead-letter table.
+type HandlerFunc func(ctx context.Context, job *Job) error
+
+// JobWorker polls Queue for runnable jobs and dispatches them to the
+// registered handler for their kind, running up to Concurrency jobs at once.
+type JobWorker struct {
+	queue        *Queue
+	logger       *zap.Logger
+	concurrency  int
+	pollInterval time.Duration
+	handlers     map[string]HandlerFunc
+}
+
+func NewJobWorker(queue *Queue, logger *zap.Logger, concurrency int) *JobWorker {
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	return &JobWorker{
+		queue:        queue,
+		logger:       logger,
+		concurrency:  concurrency,
+		pollInterval: time.Second,
+		handlers:     make(map[string]HandlerFunc),
+	}
+}
+
+// Register associates a handler with a job kind. It must be called before
+// Start for every kind the worker should process.
+func (w *JobWorker) Register(kind string, handler HandlerFunc) {
+	w.handlers[kind] = handler
+}
+
+// Start runs the polling loop until ctx is canceled, dispatching jobs to a
+// bounded pool of goroutines. Each tick drains the queue - claiming jobs one
+// at a time via Dequeue until it returns nil or the worker's concurrency
+// limit is reached - instead of claiming a single job per tick, so a burst
+// of enqueues drains at up to concurrency jobs in parallel rather than one
+// per pollInterval.
+func (w *JobWorker) Start(ctx context.Context) {
+	sem := make(chan struct{}, w.concurrency)
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.drain(ctx, sem)
+		}
+	}
+}
+
+// drain claims and dispatches runnable jobs until Dequeue reports the queue
+// is empty or every concurrency slot is occupied by an in-flight job.
+func (w *JobWorker) drain(ctx context.Context, sem chan struct{}) {
+	for {
+		select {
+		case sem <- struct{}{}:
+		default:
+			return
+		}
+
+		job, err := w.queue.Dequeue(ctx)
+		if err != nil {
+			w.logger.Error("failed to dequeue job", zap.Error(err))
+			<-sem
+			return
+		}
+		if job == nil {
+			<-sem
+			return
+		}
+
+		go func(job *Job) {
+			defer func() { <-sem }()
+			w.run(ctx, job)
+		}(job)
+	}
+}
+
+func (w *JobWorker) run(ctx context.Context, job *Job) {
+	handler, ok := w.handlers[job.Kind]
+	if !ok {
+		w.logger.Error("no handler registered for job kind", zap.String("kind", job.Kind))
+		_ = w.queue.MoveToDeadLetter(ctx, job, fmt.Errorf("no handler registered for kind %q", job.Kind))
+		return
+	}
+
+	if err := handler(ctx, job); err != nil {
+		if job.Attempts >= job.MaxAttempts {
+			if dlqErr := w.queue.MoveToDeadLetter(ctx, job, err); dlqErr != nil {
+				w.logger.Error("failed to move job to dead letter", zap.Int64("job_id", job.ID), zap.Error(dlqErr))
+			}
+			return
+		}
+
+		delay := backoff(job.Attempts)
+		if retryErr := w.queue.Retry(ctx, job, err, delay); retryErr != nil {
+			w.logger.Error("failed to schedule job retry", zap.Int64("job_id", job.ID), zap.Error(retryErr))
+		}
+		return
+	}
+
+	if err := w.queue.Succeed(ctx, job.ID); err != nil {
+		w.logger.Error("failed to mark job succeeded", zap.Int64("job_id", job.ID), zap.Error(err))
+	}
+}
+
+// backoff returns the retry delay in seconds for a given attempt count,
+// doubling each time and capping at 5 minutes.
+func backoff(attempts int) int {
+	const maxDelay = 300
+	delay := 1 << attempts
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
@@ -0,0 +1,24 @@
+package jobs
+
+import "testing"
+
+func TestBackoff(t *testing.T) {
+	cases := []struct {
+		attempts int
+		want     int
+	}{
+		{0, 1},
+		{1, 2},
+		{2, 4},
+		{5, 32},
+		{8, 256},
+		{9, 300},
+		{20, 300},
+	}
+
+	for _, c := range cases {
+		if got := backoff(c.attempts); got != c.want {
+			t.Errorf("backoff(%d) = %d, want %d", c.attempts, got, c.want)
+		}
+	}
+}
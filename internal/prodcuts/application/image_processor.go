@@ -0,0 +1,176 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/chai2010/webp"
+	"github.com/disintegration/imaging"
+	"github.com/google/uuid"
+
+	"yadwy-backend/internal/common"
+	"yadwy-backend/internal/prodcuts/domain"
+)
+
+// imageVariantSpec describes one resized rendition to derive from an
+// uploaded image.
+type imageVariantSpec struct {
+	name  string
+	width int
+}
+
+var imageVariantSpecs = []imageVariantSpec{
+	{name: "thumb", width: 256},
+	{name: "medium", width: 800},
+	{name: "large", width: 1600},
+}
+
+// ImageProcessor turns an upload already saved to storage into
+// EXIF-stripped WebP variants. It is an interface so tests can substitute a
+// no-op implementation instead of decoding real images.
+type ImageProcessor interface {
+	// ProcessStored generates variants for an original that was already
+	// saved to storage under key, e.g. by a deferred image-processing job.
+	ProcessStored(ctx context.Context, key string) (*domain.ImageVariant, error)
+	// ProcessAllStored runs ProcessStored over keys concurrently, bounded
+	// by the processor's configured worker count, and returns variants in
+	// the same order as keys.
+	ProcessAllStored(ctx context.Context, keys []string) ([]domain.ImageVariant, error)
+}
+
+// imageProcessor decodes uploads, re-encodes them (which drops EXIF/GPS
+// metadata since the decoded image.Image carries no metadata), and stores
+// thumbnail/medium/large variants as WebP. AVIF was dropped in favor of
+// WebP-only output: encoding it required a cgo binding against the system
+// libaom headers with nothing in the build to provide them, for a format
+// most clients negotiate for via Accept anyway. Concurrency is bounded by
+// maxWorkers so a request with many images can't exhaust the server's
+// goroutine/file-descriptor budget.
+type imageProcessor struct {
+	store      common.Storage
+	maxWorkers int
+}
+
+func NewImageProcessor(store common.Storage, maxWorkers int) ImageProcessor {
+	if maxWorkers <= 0 {
+		maxWorkers = 4
+	}
+	return &imageProcessor{store: store, maxWorkers: maxWorkers}
+}
+
+func (p *imageProcessor) ProcessAllStored(ctx context.Context, keys []string) ([]domain.ImageVariant, error) {
+	variants := make([]domain.ImageVariant, len(keys))
+	errs := make([]error, len(keys))
+
+	sem := make(chan struct{}, p.maxWorkers)
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		i, key := i, key
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			v, err := p.ProcessStored(ctx, key)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			variants[i] = *v
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return variants, nil
+}
+
+// ProcessStored re-downloads an original previously written to storage (via
+// its signed URL) and runs it through the variant-generation pipeline.
+func (p *imageProcessor) ProcessStored(ctx context.Context, key string) (*domain.ImageVariant, error) {
+	url, err := p.store.SignedURL(ctx, key, time.Hour)
+	if err != nil {
+		return nil, fmt.Errorf("resolve url for stored original %q: %w", key, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build request for stored original %q: %w", key, err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch stored original %q: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch stored original %q: unexpected status %s", key, resp.Status)
+	}
+
+	return p.processReader(ctx, resp.Body, key)
+}
+
+func (p *imageProcessor) processReader(ctx context.Context, src io.Reader, name string) (*domain.ImageVariant, error) {
+	img, _, err := image.Decode(src)
+	if err != nil {
+		return nil, fmt.Errorf("decode upload %q: %w", name, err)
+	}
+
+	key := uuid.NewString()
+
+	original, err := p.saveOriginal(ctx, key, img)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.ImageVariant{
+		Original: original,
+		WebP:     make(map[string]string, len(imageVariantSpecs)),
+	}
+
+	for _, spec := range imageVariantSpecs {
+		resized := imaging.Resize(img, spec.width, 0, imaging.Lanczos)
+
+		webpURL, err := p.saveWebP(ctx, key, spec.name, resized)
+		if err != nil {
+			return nil, err
+		}
+		result.WebP[spec.name] = webpURL
+
+		if spec.name == "thumb" {
+			result.Thumb = webpURL
+		}
+	}
+
+	return result, nil
+}
+
+func (p *imageProcessor) saveOriginal(ctx context.Context, key string, img image.Image) (string, error) {
+	var buf bytes.Buffer
+	if err := webp.Encode(&buf, img, &webp.Options{Lossless: false, Quality: 90}); err != nil {
+		return "", fmt.Errorf("encode original as webp: %w", err)
+	}
+	return p.store.Put(ctx, fmt.Sprintf("products/%s/original.webp", key), &buf, "image/webp")
+}
+
+func (p *imageProcessor) saveWebP(ctx context.Context, key, variant string, img image.Image) (string, error) {
+	var buf bytes.Buffer
+	if err := webp.Encode(&buf, img, &webp.Options{Lossless: false, Quality: 82}); err != nil {
+		return "", fmt.Errorf("encode %s as webp: %w", variant, err)
+	}
+	return p.store.Put(ctx, fmt.Sprintf("products/%s/%s.webp", key, variant), &buf, "image/webp")
+}
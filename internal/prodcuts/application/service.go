@@ -0,0 +1,102 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"yadwy-backend/internal/common"
+	"yadwy-backend/internal/common/errs"
+	"yadwy-backend/internal/jobs"
+	"yadwy-backend/internal/prodcuts/domain"
+)
+
+// Error codes returned by ProductService, wrapped in *errs.Error so callers
+// can render them without re-deriving an HTTP status per call site.
+const (
+	FailedToCreateProduct   = "failed-to-create-product"
+	FailedToRetrieveProduct = "failed-to-retrieve-product"
+	FailedToSearchProducts  = "failed-to-search-products"
+	ProductNotFound         = "product-not-found"
+)
+
+type ProductRepository interface {
+	Create(ctx context.Context, p *domain.Product) error
+	GetByID(ctx context.Context, id int64) (*domain.Product, error)
+	Search(ctx context.Context, params domain.SearchParams) (*domain.SearchResult, error)
+}
+
+// JobQueue is the subset of jobs.Queue the product service needs to defer
+// heavy per-product work. It is an interface so the service can be tested
+// without a database-backed queue.
+type JobQueue interface {
+	Enqueue(ctx context.Context, kind string, payload any) (*jobs.Job, error)
+}
+
+type ProductService struct {
+	repo    ProductRepository
+	storage common.Storage
+	jobs    JobQueue
+	logger  *zap.Logger
+}
+
+func NewProductService(repo ProductRepository, storage common.Storage, jobQueue JobQueue, logger *zap.Logger) *ProductService {
+	return &ProductService{repo: repo, storage: storage, jobs: jobQueue, logger: logger}
+}
+
+// CreatedProduct is the result of CreateProduct: the persisted row and the
+// ID of the image-processing job the caller can poll for completion. It
+// deliberately does not expose a URL for the raw uploaded originals — those
+// still carry EXIF/GPS metadata until the deferred image-processing job
+// strips it, so only the resulting stripped variants are ever handed back
+// to clients.
+type CreatedProduct struct {
+	Product    *domain.Product
+	ImageJobID int64
+}
+
+// CreateProduct persists p and enqueues the heavy follow-up work (image
+// variant generation, outbound webhook) instead of doing it inline, so the
+// HTTP handler can respond immediately. There is no separate search-index
+// refresh job: search_vector is a GENERATED ALWAYS ... STORED column, so
+// Create above already recomputes it synchronously.
+func (s *ProductService) CreateProduct(ctx context.Context, p *domain.Product, originalImageKeys []string) (*CreatedProduct, error) {
+	if err := s.repo.Create(ctx, p); err != nil {
+		return nil, errs.Wrap(errs.KindInternal, FailedToCreateProduct, err)
+	}
+
+	imageJob, err := s.jobs.Enqueue(ctx, jobs.KindImageProcessing, map[string]any{
+		"product_id":    p.ID,
+		"original_keys": originalImageKeys,
+	})
+	if err != nil {
+		return nil, errs.Wrap(errs.KindInternal, FailedToCreateProduct, err)
+	}
+
+	if _, err := s.jobs.Enqueue(ctx, jobs.KindOutboundWebhook, map[string]any{"product_id": p.ID, "event": "product.created"}); err != nil {
+		return nil, errs.Wrap(errs.KindInternal, FailedToCreateProduct, err)
+	}
+
+	return &CreatedProduct{Product: p, ImageJobID: imageJob.ID}, nil
+}
+
+func (s *ProductService) GetProduct(ctx context.Context, id int64) (*domain.Product, error) {
+	p, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.New(errs.KindNotFound, ProductNotFound, "product not found")
+		}
+		return nil, errs.Wrap(errs.KindInternal, FailedToRetrieveProduct, err)
+	}
+	return p, nil
+}
+
+func (s *ProductService) SearchProducts(ctx context.Context, params domain.SearchParams) (*domain.SearchResult, error) {
+	result, err := s.repo.Search(ctx, params)
+	if err != nil {
+		return nil, errs.Wrap(errs.KindInternal, FailedToSearchProducts, err)
+	}
+	return result, nil
+}
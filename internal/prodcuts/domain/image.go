@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// ImageVariant is the set of derived renditions stored for a single
+// uploaded product image; the WebP map is keyed by variant name ("thumb",
+// "medium", "large").
+type ImageVariant struct {
+	Original string            `json:"original"`
+	WebP     map[string]string `json:"webp"`
+	Thumb    string            `json:"thumb"`
+}
+
+// ImageVariants is stored as a single JSONB column so a product's images
+// round-trip through sqlx without a join.
+type ImageVariants []ImageVariant
+
+func (v ImageVariants) Value() (driver.Value, error) {
+	if v == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(v)
+}
+
+func (v *ImageVariants) Scan(src any) error {
+	if src == nil {
+		*v = nil
+		return nil
+	}
+	b, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("unsupported type for ImageVariants: %T", src)
+	}
+	return json.Unmarshal(b, v)
+}
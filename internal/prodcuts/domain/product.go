@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Product is a single catalog item owned by a seller.
+type Product struct {
+	ID          int64          `json:"id" db:"id"`
+	Name        string         `json:"name" db:"name"`
+	Description string         `json:"description" db:"description"`
+	Price       float64        `json:"price" db:"price"`
+	CategoryID  string         `json:"category_id" db:"category_id"`
+	SellerID    int64          `json:"seller_id" db:"seller_id"`
+	Stock       int            `json:"stock" db:"stock"`
+	IsAvailable bool           `json:"is_available" db:"is_available"`
+	Labels      pq.StringArray `json:"labels" db:"labels"`
+	Images      ImageVariants  `json:"images,omitempty" db:"images"`
+	CreatedAt   time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+// SearchParams narrows down a product search. Pointer fields are optional
+// filters; the zero value of Query/CategoryID/Labels means "no filter".
+type SearchParams struct {
+	Query         string
+	CategoryID    string
+	MinPrice      *float64
+	MaxPrice      *float64
+	SellerID      *int64
+	Available     *bool
+	Labels        []string
+	SortBy        string
+	SortDir       string
+	Limit         int
+	Offset        int
+	MinSimilarity float64
+}
+
+// SearchHit is a product paired with the relevance score it matched the
+// query with. Score is 0 when no query is given (browsing, with or without
+// filters and sorting).
+type SearchHit struct {
+	Product
+	Score float64 `json:"score"`
+}
+
+// SearchResult is the paginated response returned by ProductRepository.Search.
+type SearchResult struct {
+	Items  []SearchHit `json:"items"`
+	Total  int         `json:"total"`
+	Limit  int         `json:"limit"`
+	Offset int         `json:"offset"`
+}
@@ -1,12 +1,22 @@
 package infra
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"io"
 	"mime/multipart"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 	"yadwy-backend/internal/common"
+	"yadwy-backend/internal/common/errs"
+	"yadwy-backend/internal/jobs"
 	"yadwy-backend/internal/prodcuts/application"
 	"yadwy-backend/internal/prodcuts/domain"
 
@@ -16,33 +26,86 @@ import (
 )
 
 const (
-	InvalidRequestBody = "invalid-request-body"
-	InvalidProductID   = "invalid-product-id"
+	InvalidRequestBody   = "invalid-request-body"
+	InvalidProductID     = "invalid-product-id"
+	IdempotencyKeyReused = "idempotency-key-reused"
 )
 
+// idempotencyKeyTTL bounds how long a cached POST /products response stays
+// replayable before the sweeper reclaims it.
+const idempotencyKeyTTL = 24 * time.Hour
+
 type ProductHandler struct {
-	service *application.ProductService
-	logger  *zap.Logger
+	service     *application.ProductService
+	storage     common.Storage
+	jobs        *jobs.Queue
+	idempotency *common.IdempotencyStore
+	logger      *zap.Logger
 }
 
-func NewProductHandler(service *application.ProductService, logger *zap.Logger) *ProductHandler {
+func NewProductHandler(service *application.ProductService, storage common.Storage, jobQueue *jobs.Queue, idempotency *common.IdempotencyStore, logger *zap.Logger) *ProductHandler {
 	return &ProductHandler{
-		service: service,
-		logger:  logger,
+		service:     service,
+		storage:     storage,
+		jobs:        jobQueue,
+		idempotency: idempotency,
+		logger:      logger,
+	}
+}
+
+const imageProcessingConcurrency = 4
+
+// storageConfigFromEnv reads STORAGE_DRIVER and the settings its backend
+// needs. Unconfigured, it falls back to local storage in a yadwy-images
+// directory under os.TempDir(), served from http://localhost:3000/images.
+func storageConfigFromEnv() common.StorageConfig {
+	return common.StorageConfig{
+		Driver:         getEnv("STORAGE_DRIVER", "local"),
+		LocalDir:       getEnv("STORAGE_LOCAL_DIR", filepath.Join(os.TempDir(), "yadwy-images")),
+		LocalBaseURL:   getEnv("STORAGE_LOCAL_BASE_URL", "http://localhost:3000/images"),
+		S3Bucket:       getEnv("STORAGE_S3_BUCKET", ""),
+		S3Region:       getEnv("STORAGE_S3_REGION", ""),
+		S3Prefix:       getEnv("STORAGE_S3_PREFIX", "products"),
+		S3Private:      getEnv("STORAGE_S3_PRIVATE", "") == "true",
+		HTTPEndpoint:   getEnv("STORAGE_HTTP_ENDPOINT", ""),
+		HTTPAuthHeader: getEnv("STORAGE_HTTP_AUTH_HEADER", ""),
 	}
 }
 
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
 func LoadProductsRoutes(b *sqlx.DB, logger *zap.Logger, jwt *common.JWTGenerator) http.Handler {
 	ar := chi.NewRouter()
 	repo := NewProductRepository(b)
-	files, _ := common.NewFileService("/home/nerd/images", "http://localhost:3000/images")
-	srv := application.NewProductService(repo, files, logger)
-	h := NewProductHandler(srv, logger)
+
+	storage, err := common.NewStorage(storageConfigFromEnv())
+	if err != nil {
+		logger.Fatal("failed to initialize storage backend", zap.Error(err))
+	}
+
+	jobQueue := jobs.NewQueue(b)
+	srv := application.NewProductService(repo, storage, jobQueue, logger)
+
+	idempotency := common.NewIdempotencyStore(b, idempotencyKeyTTL)
+	go idempotency.StartSweeper(context.Background(), time.Hour, logger)
+
+	h := NewProductHandler(srv, storage, jobQueue, idempotency, logger)
+
+	images := application.NewImageProcessor(storage, imageProcessingConcurrency)
+	worker := jobs.NewJobWorker(jobQueue, logger, imageProcessingConcurrency)
+	RegisterJobHandlers(worker, repo, images)
+	go worker.Start(context.Background())
 
 	//ar.Use(common.GetAuthMiddlewareFunc(jwt))
-	ar.Get("/{id}", h.GetProduct)
-	ar.Post("/", h.CreateProduct)
-	ar.Get("/search", h.SearchProducts) // Add search endpoint
+	ar.Get("/{id}", errs.Middleware(h.GetProduct))
+	ar.Get("/{id}/status", errs.Middleware(h.GetProductStatus))
+	ar.Post("/", errs.Middleware(h.CreateProduct))
+	ar.Get("/search", errs.Middleware(h.SearchProducts)) // Add search endpoint
 	return ar
 }
 
@@ -66,29 +129,104 @@ type createProductRequest struct {
 // @Param main_images formData file false "Main product images"
 // @Param thumbnail_images formData file false "Thumbnail images"
 // @Param extra_images formData file false "Extra product images"
-// @Success 201 {object} domain.Product
+// @Success 202 {object} createProductResponse "Product created, image processing deferred"
 // @Failure 400 {object} common.ErrorResponse "Invalid input"
 // @Failure 500 {object} common.ErrorResponse "Server error"
 // @Router /products [post]
-func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
+func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) error {
+	idempotencyKey := r.Header.Get("Idempotency-Key")
+	if idempotencyKey == "" {
+		return h.createProduct(w, r)
+	}
+
+	// Buffer the body so it can be hashed before createProduct consumes it
+	// via ParseMultipartForm.
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return errs.Wrap(errs.KindValidation, InvalidRequestBody, err)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	requestHash := hashIdempotentRequest(idempotencyKey, body)
+
+	cached, err := h.idempotency.Get(r.Context(), idempotencyKey)
+	if err != nil {
+		return errs.Wrap(errs.KindInternal, application.FailedToCreateProduct, err)
+	}
+	if cached != nil {
+		if cached.RequestHash != requestHash {
+			return errs.New(errs.KindConflict, IdempotencyKeyReused, "Idempotency-Key was already used with a different request")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(cached.ResponseStatus)
+		_, _ = w.Write(cached.ResponseBody)
+		return nil
+	}
+
+	rec := newResponseRecorder(w)
+	if err := h.createProduct(rec, r); err != nil {
+		errs.WriteJSON(rec, err)
+	}
+
+	// Only cache terminal outcomes. A 5xx means the failure may have been
+	// transient (DB hiccup, timeout, ...), so leave the key unclaimed and
+	// let the client's retry actually attempt the request again instead of
+	// replaying a frozen failure for the rest of idempotencyKeyTTL.
+	if rec.status < http.StatusInternalServerError {
+		if err := h.idempotency.Save(r.Context(), idempotencyKey, requestHash, rec.status, rec.body.Bytes()); err != nil {
+			h.logger.Error("Failed to persist idempotency record", zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// hashIdempotentRequest hashes the Idempotency-Key alongside the request
+// body, so the same key reused for a different request is detected even
+// though keys themselves aren't guaranteed unique across clients.
+func hashIdempotentRequest(key string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(key))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder buffers everything written through it while still
+// writing through to the wrapped ResponseWriter, so a handler's response can
+// be persisted for idempotent replay without delaying the real client.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func newResponseRecorder(w http.ResponseWriter) *responseRecorder {
+	return &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (h *ProductHandler) createProduct(w http.ResponseWriter, r *http.Request) error {
 	err := r.ParseMultipartForm(10 << 20) // 10 MB max
 	if err != nil {
-		common.SendError(w, http.StatusBadRequest, InvalidRequestBody, "Failed to parse multipart form")
-		return
+		return errs.Wrap(errs.KindValidation, InvalidRequestBody, err)
 	}
 
 	// Get product data from form
 	productData := r.FormValue("product")
 	if productData == "" {
-		common.SendError(w, http.StatusBadRequest, InvalidRequestBody, "Product data is required")
-		return
+		return errs.New(errs.KindValidation, InvalidRequestBody, "Product data is required")
 	}
 
 	var req createProductRequest
 	if err := json.Unmarshal([]byte(productData), &req); err != nil {
-		h.logger.Error("Failed to decode product data", zap.Error(err))
-		common.SendError(w, http.StatusBadRequest, InvalidRequestBody, "Invalid product data format")
-		return
+		return errs.Wrap(errs.KindValidation, InvalidRequestBody, err)
 	}
 
 	mainImages := r.MultipartForm.File["main_images"]
@@ -96,8 +234,8 @@ func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 	extraImages := r.MultipartForm.File["extra_images"]
 
 	if len(mainImages) == 0 && len(thumbnailImages) == 0 {
-		common.SendError(w, http.StatusBadRequest, InvalidRequestBody, "At least one main or thumbnail image is required")
-		return
+		return errs.New(errs.KindValidation, InvalidRequestBody, "At least one main or thumbnail image is required").
+			WithFields(errs.Field{Name: "main_images", Reason: "at least one main or thumbnail image is required"})
 	}
 
 	product := &domain.Product{
@@ -125,18 +263,58 @@ func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 		allImages = append(allImages, img)
 	}
 
-	err = h.service.CreateProduct(r.Context(), product, allImages)
+	// Store the raw uploads now (cheap) and defer variant generation and
+	// webhook delivery to the job queue; no search-index job is queued, the
+	// generated search_vector column is filled in by the insert itself. Keys
+	// are content hashes so re-uploading the same file dedupes automatically.
+	originalKeys := make([]string, 0, len(allImages))
+	for _, img := range allImages {
+		key, err := h.storeOriginal(r.Context(), img)
+		if err != nil {
+			return errs.Wrap(errs.KindInternal, application.FailedToCreateProduct, err)
+		}
+		originalKeys = append(originalKeys, key)
+	}
+
+	created, err := h.service.CreateProduct(r.Context(), product, originalKeys)
 	if err != nil {
-		h.logger.Error("Failed to create product", zap.Error(err))
-		common.SendError(w, http.StatusInternalServerError, application.FailedToCreateProduct, err.Error())
-		return
+		return err
 	}
 
-	if err := common.Encode(w, http.StatusCreated, product); err != nil {
-		h.logger.Error("Failed to encode product", zap.Error(err))
-		common.SendError(w, http.StatusInternalServerError, "failed-to-encode-product", err.Error())
-		return
+	resp := createProductResponse{
+		Product:    created.Product,
+		ImageJobID: created.ImageJobID,
 	}
+	if err := common.Encode(w, http.StatusAccepted, resp); err != nil {
+		return errs.Wrap(errs.KindInternal, "failed-to-encode-product", err)
+	}
+	return nil
+}
+
+type createProductResponse struct {
+	*domain.Product
+	ImageJobID int64 `json:"image_job_id"`
+}
+
+// storeOriginal uploads fh under a content-hash key so uploading the same
+// file twice (e.g. a retried request) reuses the existing object.
+func (h *ProductHandler) storeOriginal(ctx context.Context, fh *multipart.FileHeader) (string, error) {
+	src, err := fh.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	key, data, err := common.ContentHashKey(src, "uploads", filepath.Ext(fh.Filename))
+	if err != nil {
+		return "", err
+	}
+
+	contentType := fh.Header.Get("Content-Type")
+	if _, err := h.storage.Put(ctx, key, bytes.NewReader(data), contentType); err != nil {
+		return "", err
+	}
+	return key, nil
 }
 
 // @Summary Get a product by ID
@@ -149,26 +327,82 @@ func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 // @Failure 404 {object} common.ErrorResponse "Product not found"
 // @Failure 500 {object} common.ErrorResponse "Server error"
 // @Router /products/{id} [get]
-func (h *ProductHandler) GetProduct(w http.ResponseWriter, r *http.Request) {
+func (h *ProductHandler) GetProduct(w http.ResponseWriter, r *http.Request) error {
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		common.SendError(w, http.StatusBadRequest, InvalidProductID, "Invalid product ID")
-		return
+		return errs.New(errs.KindValidation, InvalidProductID, "Invalid product ID")
 	}
 
 	product, err := h.service.GetProduct(r.Context(), id)
 	if err != nil {
-		h.logger.Error("Failed to get product", zap.Error(err))
-		common.SendError(w, http.StatusInternalServerError, application.FailedToRetrieveProduct, err.Error())
-		return
+		return err
 	}
 
 	if err := common.Encode(w, http.StatusOK, product); err != nil {
-		h.logger.Error("Failed to encode product", zap.Error(err))
-		common.SendError(w, http.StatusInternalServerError, "failed-to-encode-product", err.Error())
-		return
+		return errs.Wrap(errs.KindInternal, "failed-to-encode-product", err)
 	}
+	return nil
+}
+
+type productStatusResponse struct {
+	ProductID int64      `json:"product_id"`
+	Status    string     `json:"status"`
+	Jobs      []jobs.Job `json:"jobs"`
+}
+
+// @Summary Get the status of a product's deferred work
+// @Description Poll completion of the image-processing and webhook jobs enqueued for a product
+// @Tags products
+// @Produce json
+// @Param id path integer true "Product ID"
+// @Success 200 {object} productStatusResponse
+// @Failure 400 {object} errs.Problem "Invalid product ID"
+// @Failure 500 {object} errs.Problem "Server error"
+// @Router /products/{id}/status [get]
+func (h *ProductHandler) GetProductStatus(w http.ResponseWriter, r *http.Request) error {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return errs.New(errs.KindValidation, InvalidProductID, "Invalid product ID")
+	}
+
+	productJobs, err := h.jobs.ListByProduct(r.Context(), id)
+	if err != nil {
+		return errs.Wrap(errs.KindInternal, application.FailedToRetrieveProduct, err)
+	}
+
+	if err := common.Encode(w, http.StatusOK, productStatusResponse{
+		ProductID: id,
+		Status:    aggregateJobStatus(productJobs),
+		Jobs:      productJobs,
+	}); err != nil {
+		return errs.Wrap(errs.KindInternal, "failed-to-encode-product", err)
+	}
+	return nil
+}
+
+// aggregateJobStatus summarizes a product's deferred jobs into a single
+// status: "failed" if any job died, "processing" if any is still pending or
+// running, "completed" once every job has succeeded.
+func aggregateJobStatus(productJobs []jobs.Job) string {
+	if len(productJobs) == 0 {
+		return "unknown"
+	}
+
+	completed := true
+	for _, job := range productJobs {
+		switch job.Status {
+		case jobs.StatusDead, jobs.StatusFailed:
+			return "failed"
+		case jobs.StatusPending, jobs.StatusRunning:
+			completed = false
+		}
+	}
+	if completed {
+		return "completed"
+	}
+	return "processing"
 }
 
 // @Summary Search products
@@ -184,13 +418,14 @@ func (h *ProductHandler) GetProduct(w http.ResponseWriter, r *http.Request) {
 // @Param labels query string false "Comma-separated list of labels"
 // @Param sort_by query string false "Sort field (name, price, created_at)"
 // @Param sort_dir query string false "Sort direction (asc, desc)"
+// @Param min_similarity query number false "Minimum trigram similarity for typo-tolerant fallback (default: 0.2)"
 // @Param limit query integer false "Number of items to return (default: 10)"
 // @Param offset query integer false "Number of items to skip (default: 0)"
-// @Success 200 {array} domain.Product
+// @Success 200 {object} domain.SearchResult
 // @Failure 400 {object} common.ErrorResponse "Invalid parameters"
 // @Failure 500 {object} common.ErrorResponse "Server error"
 // @Router /products/search [get]
-func (h *ProductHandler) SearchProducts(w http.ResponseWriter, r *http.Request) {
+func (h *ProductHandler) SearchProducts(w http.ResponseWriter, r *http.Request) error {
 	query := r.URL.Query()
 
 	// Build search parameters
@@ -247,16 +482,20 @@ func (h *ProductHandler) SearchProducts(w http.ResponseWriter, r *http.Request)
 		params.Labels = strings.Split(labelsStr, ",")
 	}
 
+	if minSimilarityStr := query.Get("min_similarity"); minSimilarityStr != "" {
+		minSimilarity, err := strconv.ParseFloat(minSimilarityStr, 64)
+		if err == nil && minSimilarity >= 0 && minSimilarity <= 1 {
+			params.MinSimilarity = minSimilarity
+		}
+	}
+
 	result, err := h.service.SearchProducts(r.Context(), params)
 	if err != nil {
-		h.logger.Error("Failed to search products", zap.Error(err))
-		common.SendError(w, http.StatusInternalServerError, application.FailedToSearchProducts, err.Error())
-		return
+		return err
 	}
 
 	if err := common.Encode(w, http.StatusOK, result); err != nil {
-		h.logger.Error("Failed to encode search results", zap.Error(err))
-		common.SendError(w, http.StatusInternalServerError, "failed-to-encode-product", err.Error())
-		return
+		return errs.Wrap(errs.KindInternal, "failed-to-encode-product", err)
 	}
+	return nil
 }
@@ -0,0 +1,46 @@
+package infra
+
+import (
+	"testing"
+
+	"yadwy-backend/internal/jobs"
+)
+
+func TestHashIdempotentRequest(t *testing.T) {
+	h1 := hashIdempotentRequest("key-1", []byte(`{"name":"widget"}`))
+	h2 := hashIdempotentRequest("key-1", []byte(`{"name":"widget"}`))
+	if h1 != h2 {
+		t.Errorf("hash not stable across identical inputs: %q != %q", h1, h2)
+	}
+
+	if got := hashIdempotentRequest("key-2", []byte(`{"name":"widget"}`)); got == h1 {
+		t.Errorf("different keys produced the same hash: %q", got)
+	}
+
+	if got := hashIdempotentRequest("key-1", []byte(`{"name":"gadget"}`)); got == h1 {
+		t.Errorf("different bodies produced the same hash: %q", got)
+	}
+}
+
+func TestAggregateJobStatus(t *testing.T) {
+	cases := []struct {
+		name string
+		jobs []jobs.Job
+		want string
+	}{
+		{"no jobs", nil, "unknown"},
+		{"all succeeded", []jobs.Job{{Status: jobs.StatusSucceeded}, {Status: jobs.StatusSucceeded}}, "completed"},
+		{"one pending", []jobs.Job{{Status: jobs.StatusSucceeded}, {Status: jobs.StatusPending}}, "processing"},
+		{"one running", []jobs.Job{{Status: jobs.StatusSucceeded}, {Status: jobs.StatusRunning}}, "processing"},
+		{"one dead", []jobs.Job{{Status: jobs.StatusSucceeded}, {Status: jobs.StatusDead}}, "failed"},
+		{"one failed", []jobs.Job{{Status: jobs.StatusRunning}, {Status: jobs.StatusFailed}}, "failed"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := aggregateJobStatus(c.jobs); got != c.want {
+				t.Errorf("aggregateJobStatus(%v) = %q, want %q", c.jobs, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"yadwy-backend/internal/jobs"
+	"yadwy-backend/internal/prodcuts/application"
+)
+
+type imageProcessingPayload struct {
+	ProductID    int64    `json:"product_id"`
+	OriginalKeys []string `json:"original_keys"`
+}
+
+type outboundWebhookPayload struct {
+	ProductID int64  `json:"product_id"`
+	Event     string `json:"event"`
+}
+
+// RegisterJobHandlers wires the job kinds enqueued by ProductHandler.CreateProduct
+// to the code that actually performs them, so a JobWorker started by the
+// composition root can drain the queue.
+func RegisterJobHandlers(worker *jobs.JobWorker, repo *ProductRepository, images application.ImageProcessor) {
+	worker.Register(jobs.KindImageProcessing, func(ctx context.Context, job *jobs.Job) error {
+		var payload imageProcessingPayload
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return fmt.Errorf("decode image-processing payload: %w", err)
+		}
+
+		variants, err := images.ProcessAllStored(ctx, payload.OriginalKeys)
+		if err != nil {
+			return fmt.Errorf("process stored images for product %d: %w", payload.ProductID, err)
+		}
+
+		return repo.SetImages(ctx, payload.ProductID, variants)
+	})
+
+	worker.Register(jobs.KindOutboundWebhook, func(_ context.Context, job *jobs.Job) error {
+		var payload outboundWebhookPayload
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return fmt.Errorf("decode outbound-webhook payload: %w", err)
+		}
+		return deliverWebhook(payload)
+	})
+}
+
+// deliverWebhook is a placeholder for the outbound HTTP call to subscribers;
+// it exists so the job kind has somewhere to grow into.
+func deliverWebhook(outboundWebhookPayload) error {
+	return nil
+}
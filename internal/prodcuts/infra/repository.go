@@ -0,0 +1,219 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"yadwy-backend/internal/prodcuts/domain"
+)
+
+const defaultMinSimilarity = 0.2
+
+// productColumns lists every scannable products column domain.Product has a
+// field for. It deliberately excludes the generated search_vector column,
+// which has no corresponding struct field.
+const productColumns = `id, name, description, price, category_id, seller_id, stock, is_available, labels, images, created_at, updated_at`
+
+type ProductRepository struct {
+	db *sqlx.DB
+}
+
+func NewProductRepository(db *sqlx.DB) *ProductRepository {
+	return &ProductRepository{db: db}
+}
+
+func (r *ProductRepository) Create(ctx context.Context, p *domain.Product) error {
+	const query = `
+		INSERT INTO products (name, description, price, category_id, seller_id, stock, is_available, labels)
+		VALUES (:name, :description, :price, :category_id, :seller_id, :stock, :is_available, :labels)
+		RETURNING id, created_at, updated_at`
+
+	rows, err := r.db.NamedQueryContext(ctx, query, p)
+	if err != nil {
+		return fmt.Errorf("insert product: %w", err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		if err := rows.Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			return fmt.Errorf("scan created product: %w", err)
+		}
+	}
+	return rows.Err()
+}
+
+// SetImages stores the processed variants for a product once the deferred
+// image-processing job finishes.
+func (r *ProductRepository) SetImages(ctx context.Context, id int64, images domain.ImageVariants) error {
+	const query = `UPDATE products SET images = $2, updated_at = now() WHERE id = $1`
+	if _, err := r.db.ExecContext(ctx, query, id, images); err != nil {
+		return fmt.Errorf("set images for product %d: %w", id, err)
+	}
+	return nil
+}
+
+func (r *ProductRepository) GetByID(ctx context.Context, id int64) (*domain.Product, error) {
+	var p domain.Product
+	query := `SELECT ` + productColumns + ` FROM products WHERE id = $1`
+	if err := r.db.GetContext(ctx, &p, query, id); err != nil {
+		return nil, fmt.Errorf("get product %d: %w", id, err)
+	}
+	return &p, nil
+}
+
+// Search ranks products against params.Query using the generated
+// search_vector tsvector column, falling back to pg_trgm similarity
+// matching (against name) when the tsquery produces no hits. Category,
+// price, seller, availability and label filters apply to both paths.
+func (r *ProductRepository) Search(ctx context.Context, params domain.SearchParams) (*domain.SearchResult, error) {
+	minSimilarity := params.MinSimilarity
+	if minSimilarity <= 0 {
+		minSimilarity = defaultMinSimilarity
+	}
+
+	filters, args := buildFilterClauses(params)
+
+	if strings.TrimSpace(params.Query) != "" {
+		hits, total, err := r.searchByText(ctx, params, filters, args)
+		if err != nil {
+			return nil, err
+		}
+		if len(hits) > 0 {
+			return &domain.SearchResult{Items: hits, Total: total, Limit: params.Limit, Offset: params.Offset}, nil
+		}
+
+		hits, total, err = r.searchBySimilarity(ctx, params, filters, args, minSimilarity)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.SearchResult{Items: hits, Total: total, Limit: params.Limit, Offset: params.Offset}, nil
+	}
+
+	hits, total, err := r.browse(ctx, params, filters, args)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.SearchResult{Items: hits, Total: total, Limit: params.Limit, Offset: params.Offset}, nil
+}
+
+// buildFilterClauses renders the filters shared across every search path
+// (category/price/seller/availability/labels) as `$n`-numbered SQL
+// fragments starting at $1, so callers can append their own query-specific
+// arguments (tsquery, similarity text, ...) afterwards.
+func buildFilterClauses(params domain.SearchParams) (string, []any) {
+	var clauses []string
+	var args []any
+
+	add := func(clause string, arg any) {
+		args = append(args, arg)
+		clauses = append(clauses, fmt.Sprintf(clause, len(args)))
+	}
+
+	if params.CategoryID != "" {
+		add("category_id = $%d", params.CategoryID)
+	}
+	if params.MinPrice != nil {
+		add("price >= $%d", *params.MinPrice)
+	}
+	if params.MaxPrice != nil {
+		add("price <= $%d", *params.MaxPrice)
+	}
+	if params.SellerID != nil {
+		add("seller_id = $%d", *params.SellerID)
+	}
+	if params.Available != nil {
+		add("is_available = $%d", *params.Available)
+	}
+	if len(params.Labels) > 0 {
+		add("labels && $%d", pq.Array(params.Labels))
+	}
+
+	if len(clauses) == 0 {
+		return "", args
+	}
+	return " AND " + strings.Join(clauses, " AND "), args
+}
+
+func (r *ProductRepository) searchByText(ctx context.Context, params domain.SearchParams, filters string, args []any) ([]domain.SearchHit, int, error) {
+	queryArgPos := len(args) + 1
+	args = append(args, params.Query)
+
+	sql := fmt.Sprintf(`
+		SELECT %s, ts_rank_cd(search_vector, query) AS score, count(*) OVER() AS total
+		FROM products, websearch_to_tsquery('simple', $%d) AS query
+		WHERE search_vector @@ query%s
+		ORDER BY score DESC
+		LIMIT %d OFFSET %d`, productColumns, queryArgPos, filters, params.Limit, params.Offset)
+
+	return r.queryHits(ctx, sql, args)
+}
+
+func (r *ProductRepository) searchBySimilarity(ctx context.Context, params domain.SearchParams, filters string, args []any, minSimilarity float64) ([]domain.SearchHit, int, error) {
+	textArgPos := len(args) + 1
+	simArgPos := len(args) + 2
+	args = append(args, params.Query, minSimilarity)
+
+	sql := fmt.Sprintf(`
+		SELECT %s, similarity(name, $%d) AS score, count(*) OVER() AS total
+		FROM products
+		WHERE similarity(name, $%d) >= $%d%s
+		ORDER BY score DESC
+		LIMIT %d OFFSET %d`, productColumns, textArgPos, textArgPos, simArgPos, filters, params.Limit, params.Offset)
+
+	return r.queryHits(ctx, sql, args)
+}
+
+func (r *ProductRepository) browse(ctx context.Context, params domain.SearchParams, filters string, args []any) ([]domain.SearchHit, int, error) {
+	where := "TRUE" + filters
+	orderBy := sanitizeSort(params.SortBy, params.SortDir)
+
+	sql := fmt.Sprintf(`
+		SELECT %s, 0 AS score, count(*) OVER() AS total
+		FROM products
+		WHERE %s
+		ORDER BY %s
+		LIMIT %d OFFSET %d`, productColumns, where, orderBy, params.Limit, params.Offset)
+
+	return r.queryHits(ctx, sql, args)
+}
+
+func (r *ProductRepository) queryHits(ctx context.Context, sql string, args []any) ([]domain.SearchHit, int, error) {
+	var hits []searchHitRow
+	if err := r.db.SelectContext(ctx, &hits, sql, args...); err != nil {
+		return nil, 0, fmt.Errorf("search products: %w", err)
+	}
+
+	result := make([]domain.SearchHit, len(hits))
+	total := 0
+	for i, h := range hits {
+		result[i] = h.SearchHit
+		total = h.Total
+	}
+	return result, total, nil
+}
+
+// searchHitRow adds the count(*) OVER() window column queryHits' callers
+// all select alongside the page, so SearchResult.Total reflects the true
+// match count instead of the page size.
+type searchHitRow struct {
+	domain.SearchHit
+	Total int `db:"total"`
+}
+
+func sanitizeSort(sortBy, sortDir string) string {
+	column := "created_at"
+	switch sortBy {
+	case "name", "price", "created_at":
+		column = sortBy
+	}
+
+	dir := "DESC"
+	if strings.EqualFold(sortDir, "asc") {
+		dir = "ASC"
+	}
+	return column + " " + dir
+}
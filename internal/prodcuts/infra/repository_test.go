@@ -0,0 +1,67 @@
+package infra
+
+import (
+	"testing"
+
+	"yadwy-backend/internal/prodcuts/domain"
+)
+
+func TestSanitizeSort(t *testing.T) {
+	cases := []struct {
+		name    string
+		sortBy  string
+		sortDir string
+		want    string
+	}{
+		{"defaults to created_at desc", "", "", "created_at DESC"},
+		{"accepts name", "name", "asc", "name ASC"},
+		{"accepts price", "price", "", "price DESC"},
+		{"rejects unknown column", "seller_id", "asc", "created_at ASC"},
+		{"sort dir is case-insensitive", "price", "ASC", "price ASC"},
+		{"unknown sort dir defaults to desc", "price", "sideways", "price DESC"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := sanitizeSort(c.sortBy, c.sortDir); got != c.want {
+				t.Errorf("sanitizeSort(%q, %q) = %q, want %q", c.sortBy, c.sortDir, got, c.want)
+			}
+		})
+	}
+}
+
+func TestBuildFilterClauses(t *testing.T) {
+	t.Run("no filters", func(t *testing.T) {
+		clause, args := buildFilterClauses(domain.SearchParams{})
+		if clause != "" {
+			t.Errorf("clause = %q, want empty", clause)
+		}
+		if len(args) != 0 {
+			t.Errorf("args = %v, want empty", args)
+		}
+	})
+
+	t.Run("numbers clauses starting at $1", func(t *testing.T) {
+		minPrice := 10.0
+		maxPrice := 20.0
+		sellerID := int64(7)
+		available := true
+
+		clause, args := buildFilterClauses(domain.SearchParams{
+			CategoryID: "cat-1",
+			MinPrice:   &minPrice,
+			MaxPrice:   &maxPrice,
+			SellerID:   &sellerID,
+			Available:  &available,
+			Labels:     []string{"eco-friendly"},
+		})
+
+		const want = " AND category_id = $1 AND price >= $2 AND price <= $3 AND seller_id = $4 AND is_available = $5 AND labels && $6"
+		if clause != want {
+			t.Errorf("clause = %q, want %q", clause, want)
+		}
+		if len(args) != 6 {
+			t.Errorf("len(args) = %d, want 6", len(args))
+		}
+	})
+}